@@ -0,0 +1,218 @@
+package lru
+
+import "time"
+
+// ARCCache implements the Adaptive Replacement Cache (ARC) policy. It
+// adaptively balances recency (T1) against frequency (T2) by tracking
+// two ghost lists, B1 and B2, of recently evicted keys, and shifting a
+// target size p toward whichever ghost list a hit lands in.
+type ARCCache struct {
+	Expiry time.Duration
+
+	// OnEvicted optionally specifies a callback function to be
+	// executed when an entry is purged from the cache.
+	OnEvicted func(key string, value interface{})
+
+	size int
+	p    int
+
+	// suppress is set around the Remove half of a T1<->T2 promotion,
+	// so the promotion's internal removal does not get reported to
+	// OnEvicted: the entry isn't leaving the cache, just moving lists.
+	suppress bool
+
+	t1 *Cache
+	t2 *Cache
+	b1 *Cache
+	b2 *Cache
+}
+
+func NewARCCache(size int, options ...func(*ARCCache)) *ARCCache {
+	c := &ARCCache{
+		size: size,
+		t1:   New(0),
+		t2:   New(0),
+		b1:   New(size),
+		b2:   New(size),
+	}
+	forward := func(key string, value interface{}) {
+		if !c.suppress && c.OnEvicted != nil {
+			c.OnEvicted(key, value)
+		}
+	}
+	c.t1.OnEvicted = forward
+	c.t2.OnEvicted = forward
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func WithExpiryARC(expiry time.Duration) func(c *ARCCache) {
+	return func(c *ARCCache) {
+		c.Expiry = expiry
+		c.t1.Expiry = expiry
+		c.t2.Expiry = expiry
+	}
+}
+
+func WithEvictionCallbackARC(onEvicted func(key string, value interface{})) func(c *ARCCache) {
+	return func(c *ARCCache) {
+		c.OnEvicted = onEvicted
+	}
+}
+
+func (c *ARCCache) Add(key string, value interface{}) {
+	if _, ok := c.t1.Get(key); ok {
+		c.promote(key, value)
+		return
+	}
+	if _, ok := c.t2.Get(key); ok {
+		c.t2.UpdateElement(key, value)
+		return
+	}
+	if _, ok := c.b1.Get(key); ok {
+		delta := 1
+		if c.b1.Len() < c.b2.Len() {
+			delta = c.b2.Len() / c.b1.Len()
+		}
+		c.p = min(c.p+delta, c.size)
+		c.replace(key)
+		c.b1.Remove(key)
+		c.t2.Add(key, value)
+		return
+	}
+	if _, ok := c.b2.Get(key); ok {
+		delta := 1
+		if c.b2.Len() < c.b1.Len() {
+			delta = c.b1.Len() / c.b2.Len()
+		}
+		c.p = max(c.p-delta, 0)
+		c.replace(key)
+		c.b2.Remove(key)
+		c.t2.Add(key, value)
+		return
+	}
+	if c.t1.Len()+c.t2.Len() >= c.size {
+		if c.t1.Len()+c.b1.Len() == c.size {
+			if c.t1.Len() < c.size {
+				c.evictGhost(c.b1)
+				c.replace(key)
+			} else {
+				c.evictCache(c.t1)
+			}
+		} else {
+			c.replace(key)
+		}
+	}
+	c.t1.Add(key, value)
+}
+
+func (c *ARCCache) Get(key string) (value interface{}, ok bool) {
+	if value, ok = c.t1.Get(key); ok {
+		c.promote(key, value)
+		return value, true
+	}
+	if value, ok = c.t2.Get(key); ok {
+		return value, true
+	}
+	return nil, false
+}
+
+// promote moves key/value from T1 to T2 without reporting the move to
+// OnEvicted: the entry is still live in the cache, just recorded as
+// having been referenced twice.
+func (c *ARCCache) promote(key string, value interface{}) {
+	c.suppress = true
+	c.t1.Remove(key)
+	c.suppress = false
+	c.t2.Add(key, value)
+}
+
+func (c *ARCCache) Remove(key string) {
+	if _, ok := c.t1.Get(key); ok {
+		c.t1.Remove(key)
+		return
+	}
+	if _, ok := c.t2.Get(key); ok {
+		c.t2.Remove(key)
+		return
+	}
+	c.b1.Remove(key)
+	c.b2.Remove(key)
+}
+
+// replace evicts a single entry from T1 or T2 into its corresponding
+// ghost list. It takes T1's oldest entry when T1 is non-empty and either
+// larger than the target p, or equal to p while key is in B2; otherwise
+// it takes T2's oldest entry, if T2 is non-empty.
+func (c *ARCCache) replace(key string) {
+	if c.t1.Len() > 0 && (c.t1.Len() > c.p || (c.t1.Len() == c.p && c.inB2(key))) {
+		c.evictToGhost(c.t1, c.b1)
+		return
+	}
+	if c.t2.Len() > 0 {
+		c.evictToGhost(c.t2, c.b2)
+	}
+}
+
+func (c *ARCCache) inB2(key string) bool {
+	_, ok := c.b2.Get(key)
+	return ok
+}
+
+// evictToGhost evicts the oldest entry in from into ghost. from.OnEvicted
+// is wired to the outer OnEvicted, so the eviction is reported there.
+func (c *ARCCache) evictToGhost(from, ghost *Cache) {
+	ele := from.ll.Back()
+	if ele == nil {
+		return
+	}
+	kv := ele.Value.(*entry)
+	from.Remove(kv.key)
+	ghost.Add(kv.key, nil)
+}
+
+// evictCache evicts the oldest entry in from with no ghost list to move
+// it to. from.OnEvicted is wired to the outer OnEvicted, so the eviction
+// is reported there.
+func (c *ARCCache) evictCache(from *Cache) {
+	ele := from.ll.Back()
+	if ele == nil {
+		return
+	}
+	kv := ele.Value.(*entry)
+	from.Remove(kv.key)
+}
+
+func (c *ARCCache) evictGhost(ghost *Cache) {
+	ghost.RemoveOldest()
+}
+
+// Len returns the number of items in the cache.
+func (c *ARCCache) Len() int {
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Clear purges all stored items from the cache.
+func (c *ARCCache) Clear() {
+	c.t1.Clear()
+	c.t2.Clear()
+	c.b1.Clear()
+	c.b2.Clear()
+	c.p = 0
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
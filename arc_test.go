@@ -0,0 +1,96 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestARCCacheAddGetRemove(t *testing.T) {
+	c := NewARCCache(4)
+	c.Add("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be removed")
+	}
+}
+
+func TestARCCacheStaysWithinSize(t *testing.T) {
+	c := NewARCCache(4)
+	for i := 0; i < 100; i++ {
+		c.Add(string(rune('a'+i%26)), i)
+		if c.Len() > 4 {
+			t.Fatalf("Len() = %d, want <= 4 after inserting %d entries", c.Len(), i+1)
+		}
+	}
+}
+
+func TestARCCachePromotesOnSecondHit(t *testing.T) {
+	c := NewARCCache(4)
+	c.Add("a", 1)
+	if c.t1.Len() != 1 || c.t2.Len() != 0 {
+		t.Fatalf("after one Add, t1=%d t2=%d; want t1=1 t2=0", c.t1.Len(), c.t2.Len())
+	}
+	c.Get("a")
+	if c.t1.Len() != 0 || c.t2.Len() != 1 {
+		t.Fatalf("after a second reference, t1=%d t2=%d; want t1=0 t2=1", c.t1.Len(), c.t2.Len())
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1 (promotion must not duplicate the entry)", c.Len())
+	}
+}
+
+func TestARCCacheOnEvictedFiresOnSizeEviction(t *testing.T) {
+	var evicted []string
+	c := NewARCCache(2, WithEvictionCallbackARC(func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	}))
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+	if len(evicted) == 0 {
+		t.Fatalf("expected OnEvicted to fire on size-based eviction")
+	}
+}
+
+func TestARCCacheOnEvictedFiresOnExpiry(t *testing.T) {
+	var evicted []string
+	c := NewARCCache(4,
+		WithExpiryARC(10*time.Millisecond),
+		WithEvictionCallbackARC(func(key string, value interface{}) {
+			evicted = append(evicted, key)
+		}),
+	)
+	c.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to have expired")
+	}
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("evicted = %v, want [a] (expiry must be reported to OnEvicted)", evicted)
+	}
+}
+
+func TestARCCachePromotionDoesNotFireOnEvicted(t *testing.T) {
+	var evicted []string
+	c := NewARCCache(4, WithEvictionCallbackARC(func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	}))
+	c.Add("a", 1)
+	c.Get("a")
+	if len(evicted) != 0 {
+		t.Fatalf("evicted = %v, want none; promoting a from T1 to T2 must not evict it", evicted)
+	}
+}
+
+func TestARCCacheClear(t *testing.T) {
+	c := NewARCCache(4)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Clear()
+	if c.Len() != 0 {
+		t.Fatalf("Len() after Clear() = %d, want 0", c.Len())
+	}
+}
@@ -0,0 +1,68 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddWithExpiryPerEntryTTL(t *testing.T) {
+	c := New(0)
+	c.AddWithExpiry("short", 1, 10*time.Millisecond)
+	c.AddWithExpiry("long", 2, time.Hour)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("short"); ok {
+		t.Fatalf("expected short to have expired")
+	}
+	if v, ok := c.Get("long"); !ok || v != 2 {
+		t.Fatalf("Get(long) = %v, %v; want 2, true", v, ok)
+	}
+}
+
+func TestAddWithExpiryFallsBackToCacheExpiry(t *testing.T) {
+	c := New(0, WithExpiry(10*time.Millisecond))
+	c.AddWithExpiry("a", 1, 0)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to fall back to Cache.Expiry and expire")
+	}
+}
+
+func TestGetExpiration(t *testing.T) {
+	c := New(0)
+	c.Add("no-ttl", 1)
+	c.AddWithExpiry("ttl", 2, time.Hour)
+
+	if _, ok := c.GetExpiration("missing"); ok {
+		t.Fatalf("GetExpiration(missing) returned ok=true")
+	}
+	deadline, ok := c.GetExpiration("no-ttl")
+	if !ok || !deadline.IsZero() {
+		t.Fatalf("GetExpiration(no-ttl) = %v, %v; want zero time, true", deadline, ok)
+	}
+	deadline, ok = c.GetExpiration("ttl")
+	if !ok || deadline.Before(time.Now()) {
+		t.Fatalf("GetExpiration(ttl) = %v, %v; want a future deadline, true", deadline, ok)
+	}
+}
+
+func TestSyncCacheStartExpiryPurgerEvictsProactively(t *testing.T) {
+	evicted := make(chan string, 1)
+	c := NewSync(0, WithExpiry(10*time.Millisecond), WithEvictionCallback(func(key string, value interface{}) {
+		evicted <- key
+	}))
+	c.StartExpiryPurger(5 * time.Millisecond)
+	defer c.Close()
+
+	c.Add("a", 1)
+
+	select {
+	case key := <-evicted:
+		if key != "a" {
+			t.Fatalf("evicted key = %q, want %q", key, "a")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the purger to proactively evict the expired entry")
+	}
+}
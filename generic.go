@@ -0,0 +1,131 @@
+package lru
+
+import (
+	"container/list"
+	"time"
+)
+
+// CacheG is a generic counterpart to Cache: it is keyed on any comparable
+// type and stores values of any type without boxing them into
+// interface{}, avoiding the allocations that come with that boxing.
+type CacheG[K comparable, V any] struct {
+	Expiry time.Duration
+	Size   int
+
+	// OnEvicted optionally specifies a callback function to be
+	// executed when an entry is purged from the cache.
+	OnEvicted func(key K, value V)
+
+	ll    *list.List
+	cache map[K]*list.Element
+}
+
+type entryG[K comparable, V any] struct {
+	key        K
+	value      V
+	timeInsert int64
+}
+
+func NewG[K comparable, V any](size int, options ...func(*CacheG[K, V])) *CacheG[K, V] {
+	c := &CacheG[K, V]{Size: size, cache: make(map[K]*list.Element), ll: list.New()}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func WithExpiryG[K comparable, V any](expiry time.Duration) func(c *CacheG[K, V]) {
+	return func(c *CacheG[K, V]) {
+		c.Expiry = expiry
+	}
+}
+
+func WithEvictionCallbackG[K comparable, V any](onEvicted func(key K, value V)) func(c *CacheG[K, V]) {
+	return func(c *CacheG[K, V]) {
+		c.OnEvicted = onEvicted
+	}
+}
+
+func (c *CacheG[K, V]) Add(key K, value V) {
+	var epochNow int64
+	if c.Expiry != time.Duration(0) {
+		epochNow = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+	if ee, ok := c.cache[key]; ok {
+		c.ll.MoveToFront(ee)
+		ee.Value.(*entryG[K, V]).value = value
+		ee.Value.(*entryG[K, V]).timeInsert = epochNow
+		return
+	}
+	ele := c.ll.PushFront(&entryG[K, V]{key, value, epochNow})
+	c.cache[key] = ele
+	if c.Size != 0 && c.ll.Len() > c.Size {
+		c.RemoveOldest()
+	}
+	return
+}
+
+func (c *CacheG[K, V]) Get(key K) (value V, ok bool) {
+	if ele, hit := c.cache[key]; hit {
+		if c.Expiry != time.Duration(0) {
+			unixNow := time.Now().UnixNano() / int64(time.Millisecond)
+			unixExpiry := int64(c.Expiry / time.Millisecond)
+			if (unixNow - ele.Value.(*entryG[K, V]).timeInsert) > unixExpiry {
+				c.removeElement(ele)
+				var zero V
+				return zero, false
+			}
+		}
+		c.ll.MoveToFront(ele)
+		return ele.Value.(*entryG[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *CacheG[K, V]) Remove(key K) {
+	if ele, hit := c.cache[key]; hit {
+		c.removeElement(ele)
+	}
+}
+
+// Updates element's value without updating it's "Least-Recently-Used" status
+func (c *CacheG[K, V]) UpdateElement(key K, value V) {
+	if ee, ok := c.cache[key]; ok {
+		ee.Value.(*entryG[K, V]).value = value
+		return
+	}
+}
+
+func (c *CacheG[K, V]) RemoveOldest() {
+	ele := c.ll.Back()
+	if ele != nil {
+		c.removeElement(ele)
+	}
+}
+
+func (c *CacheG[K, V]) removeElement(e *list.Element) {
+	c.ll.Remove(e)
+	kv := e.Value.(*entryG[K, V])
+	delete(c.cache, kv.key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
+
+// Len returns the number of items in the cache.
+func (c *CacheG[K, V]) Len() int {
+	return c.ll.Len()
+}
+
+// Clear purges all stored items from the cache.
+func (c *CacheG[K, V]) Clear() {
+	for _, e := range c.cache {
+		kv := e.Value.(*entryG[K, V])
+		if c.OnEvicted != nil {
+			c.OnEvicted(kv.key, kv.value)
+		}
+		delete(c.cache, kv.key)
+	}
+	c.ll.Init()
+}
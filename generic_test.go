@@ -0,0 +1,87 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheGAddGet(t *testing.T) {
+	c := NewG[string, int](0)
+	c.Add("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Fatalf("Get(missing) returned ok=true")
+	}
+}
+
+func TestCacheGNonStringKey(t *testing.T) {
+	c := NewG[[2]byte, string](0)
+	key := [2]byte{1, 2}
+	c.Add(key, "hello")
+	if v, ok := c.Get(key); !ok || v != "hello" {
+		t.Fatalf("Get(key) = %v, %v; want hello, true", v, ok)
+	}
+}
+
+func TestCacheGEvictsOldestOnOverflow(t *testing.T) {
+	c := NewG[string, int](2)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be evicted")
+	}
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", c.Len())
+	}
+}
+
+func TestCacheGRemoveAndUpdateElement(t *testing.T) {
+	c := NewG[string, int](0)
+	c.Add("a", 1)
+	c.UpdateElement("a", 2)
+	if v, ok := c.Get("a"); !ok || v != 2 {
+		t.Fatalf("Get(a) after UpdateElement = %v, %v; want 2, true", v, ok)
+	}
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be removed")
+	}
+}
+
+func TestCacheGRemoveOldestAndClear(t *testing.T) {
+	c := NewG[string, int](0)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.RemoveOldest()
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a (oldest) to be removed")
+	}
+	c.Clear()
+	if c.Len() != 0 {
+		t.Fatalf("Len() after Clear() = %d, want 0", c.Len())
+	}
+}
+
+func TestCacheGWithExpiryG(t *testing.T) {
+	c := NewG[string, int](0, WithExpiryG[string, int](10*time.Millisecond))
+	c.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to have expired")
+	}
+}
+
+func TestCacheGWithEvictionCallbackG(t *testing.T) {
+	var evicted []string
+	c := NewG[string, int](1, WithEvictionCallbackG[string, int](func(key string, value int) {
+		evicted = append(evicted, key)
+	}))
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("evicted = %v, want [a]", evicted)
+	}
+}
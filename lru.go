@@ -6,10 +6,21 @@ package lru
 
 import (
 	"container/list"
+	"sync/atomic"
 	"time"
 )
 
 type Cache struct {
+	// hits, misses, evictions, expirations and adds are accessed
+	// atomically and must stay first in the struct so they remain
+	// 64-bit aligned on 32-bit platforms.
+	hits        int64
+	misses      int64
+	evictions   int64
+	expirations int64
+	adds        int64
+	length      int64
+
 	Expiry time.Duration
 	Size   int
 
@@ -22,9 +33,11 @@ type Cache struct {
 }
 
 type entry struct {
-	key        string
-	value      interface{}
-	timeInsert int64
+	key   string
+	value interface{}
+	// expireAt is the absolute deadline for this entry, in
+	// milliseconds since the epoch, or 0 if it never expires.
+	expireAt int64
 }
 
 func New(size int, options ...func(*Cache)) *Cache {
@@ -48,18 +61,38 @@ func WithEvictionCallback(onEvicted func(key string, value interface{})) func(c
 }
 
 func (c *Cache) Add(key string, value interface{}) {
-	var epochNow int64
-	if c.Expiry != time.Duration(0) {
-		epochNow = time.Now().UnixNano() / int64(time.Millisecond)
+	c.addWithDeadline(key, value, c.deadline(c.Expiry))
+}
+
+// AddWithExpiry inserts key/value with its own ttl instead of the
+// cache-wide Expiry, so callers can mix entries with heterogeneous
+// lifetimes (e.g. a DNS cache keyed by per-record TTL). A ttl of 0
+// falls back to Cache.Expiry.
+func (c *Cache) AddWithExpiry(key string, value interface{}, ttl time.Duration) {
+	if ttl == time.Duration(0) {
+		ttl = c.Expiry
+	}
+	c.addWithDeadline(key, value, c.deadline(ttl))
+}
+
+func (c *Cache) deadline(ttl time.Duration) int64 {
+	if ttl == time.Duration(0) {
+		return 0
 	}
+	return time.Now().UnixNano()/int64(time.Millisecond) + int64(ttl/time.Millisecond)
+}
+
+func (c *Cache) addWithDeadline(key string, value interface{}, expireAt int64) {
+	atomic.AddInt64(&c.adds, 1)
 	if ee, ok := c.cache[key]; ok {
 		c.ll.MoveToFront(ee)
 		ee.Value.(*entry).value = value
-		ee.Value.(*entry).timeInsert = epochNow
+		ee.Value.(*entry).expireAt = expireAt
 		return
 	}
-	ele := c.ll.PushFront(&entry{key, value, epochNow})
+	ele := c.ll.PushFront(&entry{key, value, expireAt})
 	c.cache[key] = ele
+	atomic.AddInt64(&c.length, 1)
 	if c.Size != 0 && c.ll.Len() > c.Size {
 		c.RemoveOldest()
 	}
@@ -68,20 +101,98 @@ func (c *Cache) Add(key string, value interface{}) {
 
 func (c *Cache) Get(key string) (value interface{}, ok bool) {
 	if ele, hit := c.cache[key]; hit {
-		if c.Expiry != time.Duration(0) {
-			unixNow := time.Now().UnixNano() / int64(time.Millisecond)
-			unixExpiry := int64(c.Expiry / time.Millisecond)
-			if (unixNow - ele.Value.(*entry).timeInsert) > unixExpiry {
-				c.removeElement(ele)
-				return nil, false
-			}
+		en := ele.Value.(*entry)
+		if en.expireAt != 0 && time.Now().UnixNano()/int64(time.Millisecond) > en.expireAt {
+			atomic.AddInt64(&c.expirations, 1)
+			c.removeElement(ele)
+			return nil, false
 		}
+		atomic.AddInt64(&c.hits, 1)
 		c.ll.MoveToFront(ele)
+		return en.value, true
+	}
+	atomic.AddInt64(&c.misses, 1)
+	return nil, false
+}
+
+// Peek returns the value associated with key without updating its
+// "Least-Recently-Used" order or triggering expiry, so callers such as
+// metrics scrapers and admin tooling can inspect the cache without
+// perturbing it.
+func (c *Cache) Peek(key string) (value interface{}, ok bool) {
+	if ele, hit := c.cache[key]; hit {
 		return ele.Value.(*entry).value, true
 	}
 	return nil, false
 }
 
+// Keys returns the keys currently in the cache, ordered from least to
+// most recently used.
+func (c *Cache) Keys() []string {
+	keys := make([]string, 0, c.ll.Len())
+	for e := c.ll.Back(); e != nil; e = e.Prev() {
+		keys = append(keys, e.Value.(*entry).key)
+	}
+	return keys
+}
+
+// Stats holds a snapshot of cache counters, safe to read concurrently
+// from a metrics scraper.
+type Stats struct {
+	Hits        int64
+	Misses      int64
+	Evictions   int64
+	Expirations int64
+	Adds        int64
+	Len         int
+	Size        int
+}
+
+// Stats returns a snapshot of the cache's hit/miss/eviction counters.
+// Stats is safe to call concurrently with Add/Get/Remove from other
+// goroutines: Hits, Misses, Evictions, Expirations, Adds and Len come
+// from atomic counters rather than the unsynchronized list/map
+// underneath the cache, and Size is the cache's Size field. Len uses
+// the same counter as the standalone Len method, so the two never
+// disagree.
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:        atomic.LoadInt64(&c.hits),
+		Misses:      atomic.LoadInt64(&c.misses),
+		Evictions:   atomic.LoadInt64(&c.evictions),
+		Expirations: atomic.LoadInt64(&c.expirations),
+		Adds:        atomic.LoadInt64(&c.adds),
+		Len:         c.Len(),
+		Size:        c.Size,
+	}
+}
+
+// GetExpiration returns the absolute deadline for key, and whether key
+// is present in the cache. A zero time.Time means the entry has no
+// expiration.
+func (c *Cache) GetExpiration(key string) (time.Time, bool) {
+	ele, hit := c.cache[key]
+	if !hit {
+		return time.Time{}, false
+	}
+	expireAt := ele.Value.(*entry).expireAt
+	if expireAt == 0 {
+		return time.Time{}, true
+	}
+	return time.UnixMilli(expireAt), true
+}
+
+// purgeExpired evicts every entry whose deadline has passed.
+func (c *Cache) purgeExpired() {
+	unixNow := time.Now().UnixNano() / int64(time.Millisecond)
+	for _, ele := range c.cache {
+		if en := ele.Value.(*entry); en.expireAt != 0 && unixNow > en.expireAt {
+			atomic.AddInt64(&c.expirations, 1)
+			c.removeElement(ele)
+		}
+	}
+}
+
 func (c *Cache) Remove(key string) {
 	if ele, hit := c.cache[key]; hit {
 		c.removeElement(ele)
@@ -99,6 +210,7 @@ func (c *Cache) UpdateElement(key string, value interface{}) {
 func (c *Cache) RemoveOldest() {
 	ele := c.ll.Back()
 	if ele != nil {
+		atomic.AddInt64(&c.evictions, 1)
 		c.removeElement(ele)
 	}
 }
@@ -107,6 +219,7 @@ func (c *Cache) removeElement(e *list.Element) {
 	c.ll.Remove(e)
 	kv := e.Value.(*entry)
 	delete(c.cache, kv.key)
+	atomic.AddInt64(&c.length, -1)
 	if c.OnEvicted != nil {
 		c.OnEvicted(kv.key, kv.value)
 	}
@@ -114,7 +227,7 @@ func (c *Cache) removeElement(e *list.Element) {
 
 // Len returns the number of items in the cache.
 func (c *Cache) Len() int {
-	return c.ll.Len()
+	return int(atomic.LoadInt64(&c.length))
 }
 
 // Clear purges all stored items from the cache.
@@ -127,4 +240,5 @@ func (c *Cache) Clear() {
 		delete(c.cache, kv.key)
 	}
 	c.ll.Init()
+	atomic.StoreInt64(&c.length, 0)
 }
@@ -0,0 +1,68 @@
+package lru
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// zipfianWorkload builds an access sequence drawn from a Zipfian
+// distribution over hotKeys popular keys, with periodic scan bursts of
+// keys that are never seen again. The scan bursts are what expose LRU's
+// weakness: they flush popular entries out of the recency list even
+// though they'll never be reused, whereas SIEVE's visited bit protects
+// entries that were actually re-referenced.
+func zipfianWorkload(hotKeys, accesses, scanEvery, scanLen int) []string {
+	r := rand.New(rand.NewSource(1))
+	z := rand.NewZipf(r, 1.2, 1, uint64(hotKeys-1))
+	keys := make([]string, 0, accesses)
+	scanID := 0
+	for i := 0; i < accesses; i++ {
+		if scanEvery > 0 && i%scanEvery == 0 {
+			for j := 0; j < scanLen; j++ {
+				keys = append(keys, fmt.Sprintf("scan-%d-%d", scanID, j))
+			}
+			scanID++
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("hot-%d", z.Uint64()))
+	}
+	return keys
+}
+
+func hitRateLRU(size int, keys []string) float64 {
+	c := New(size)
+	hits := 0
+	for _, k := range keys {
+		if _, ok := c.Get(k); ok {
+			hits++
+			continue
+		}
+		c.Add(k, k)
+	}
+	return float64(hits) / float64(len(keys))
+}
+
+func hitRateSieve(size int, keys []string) float64 {
+	s := NewSieve(size)
+	hits := 0
+	for _, k := range keys {
+		if _, ok := s.Get(k); ok {
+			hits++
+			continue
+		}
+		s.Add(k, k)
+	}
+	return float64(hits) / float64(len(keys))
+}
+
+func TestSieveHitRateBeatsLRUOnZipfianWithScans(t *testing.T) {
+	keys := zipfianWorkload(200, 20000, 500, 100)
+
+	lruRate := hitRateLRU(64, keys)
+	sieveRate := hitRateSieve(64, keys)
+
+	if sieveRate <= lruRate {
+		t.Fatalf("expected Sieve hit rate (%.4f) to beat LRU hit rate (%.4f) on a scan-polluted Zipfian workload", sieveRate, lruRate)
+	}
+}
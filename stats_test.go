@@ -0,0 +1,101 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsCountsHitsMissesAddsAndEvictions(t *testing.T) {
+	c := New(2)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Get("a")       // hit
+	c.Get("missing") // miss
+	c.Add("c", 3)     // evicts b (a was just touched, so it's the MRU entry)
+
+	stats := c.Stats()
+	if stats.Adds != 3 {
+		t.Fatalf("Adds = %d, want 3", stats.Adds)
+	}
+	if stats.Hits != 1 {
+		t.Fatalf("Hits = %d, want 1", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Fatalf("Misses = %d, want 1", stats.Misses)
+	}
+	if stats.Evictions != 1 {
+		t.Fatalf("Evictions = %d, want 1", stats.Evictions)
+	}
+	if stats.Len != c.Len() {
+		t.Fatalf("Stats().Len = %d, want Len() = %d", stats.Len, c.Len())
+	}
+}
+
+func TestStatsCountsExpirations(t *testing.T) {
+	c := New(0, WithExpiry(10*time.Millisecond))
+	c.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+	c.Get("a")
+
+	if stats := c.Stats(); stats.Expirations != 1 {
+		t.Fatalf("Expirations = %d, want 1", stats.Expirations)
+	}
+}
+
+func TestStatsLenMatchesLen(t *testing.T) {
+	c := New(0)
+	for i := 0; i < 5; i++ {
+		c.Add(string(rune('a'+i)), i)
+	}
+	c.Remove("a")
+
+	if stats := c.Stats(); stats.Len != 4 || stats.Len != c.Len() {
+		t.Fatalf("Stats().Len = %d, Len() = %d; want both 4", stats.Len, c.Len())
+	}
+}
+
+func TestKeysOrderedLeastToMostRecentlyUsed(t *testing.T) {
+	c := New(0)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+	c.Get("a") // moves a to the front
+
+	keys := c.Keys()
+	want := []string{"b", "c", "a"}
+	if len(keys) != len(want) {
+		t.Fatalf("Keys() = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("Keys() = %v, want %v", keys, want)
+		}
+	}
+}
+
+func TestPeekDoesNotUpdateOrder(t *testing.T) {
+	c := New(2)
+	c.Add("a", 1)
+	c.Add("b", 2)
+
+	if v, ok := c.Peek("a"); !ok || v != 1 {
+		t.Fatalf("Peek(a) = %v, %v; want 1, true", v, ok)
+	}
+
+	// a is still the least-recently-used entry because Peek must not
+	// have promoted it, so adding c should evict a, not b.
+	c.Add("c", 3)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be evicted: Peek must not affect LRU order")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatalf("expected b to survive eviction")
+	}
+}
+
+func TestPeekMissingKey(t *testing.T) {
+	c := New(0)
+	if _, ok := c.Peek("missing"); ok {
+		t.Fatalf("Peek(missing) returned ok=true")
+	}
+}
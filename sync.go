@@ -0,0 +1,108 @@
+package lru
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncCache wraps a Cache with a sync.RWMutex so that it is safe for
+// concurrent use. Get still takes the write lock because it mutates the
+// LRU order and can trigger expiry-based eviction. StartExpiryPurger is
+// the only safe way to proactively sweep expired entries: there is no
+// unlocked equivalent, since a sweeper goroutine is itself a concurrent
+// accessor of the cache.
+type SyncCache struct {
+	mu        sync.RWMutex
+	cache     *Cache
+	purgeStop chan struct{}
+}
+
+func NewSync(size int, options ...func(*Cache)) *SyncCache {
+	return &SyncCache{cache: New(size, options...)}
+}
+
+// StartExpiryPurger starts a background goroutine that wakes up every
+// interval and proactively evicts expired entries under SyncCache's own
+// lock, so OnEvicted fires promptly instead of only on access, without
+// racing concurrent Add/Get. Callers must call Close when the SyncCache
+// is no longer needed, or the goroutine leaks for the life of the
+// process.
+func (c *SyncCache) StartExpiryPurger(interval time.Duration) {
+	c.mu.Lock()
+	c.purgeStop = make(chan struct{})
+	stop := c.purgeStop
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				c.mu.Lock()
+				c.cache.purgeExpired()
+				c.mu.Unlock()
+			case <-stop:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the background goroutine started by StartExpiryPurger, if
+// any. It is a no-op on a SyncCache on which StartExpiryPurger was not
+// called.
+func (c *SyncCache) Close() {
+	c.mu.Lock()
+	stop := c.purgeStop
+	c.purgeStop = nil
+	c.mu.Unlock()
+	if stop != nil {
+		close(stop)
+	}
+}
+
+func (c *SyncCache) Add(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(key, value)
+}
+
+func (c *SyncCache) Get(key string) (value interface{}, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Get(key)
+}
+
+func (c *SyncCache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Remove(key)
+}
+
+// Updates element's value without updating it's "Least-Recently-Used" status
+func (c *SyncCache) UpdateElement(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.UpdateElement(key, value)
+}
+
+func (c *SyncCache) RemoveOldest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.RemoveOldest()
+}
+
+// Len returns the number of items in the cache.
+func (c *SyncCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cache.Len()
+}
+
+// Clear purges all stored items from the cache.
+func (c *SyncCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Clear()
+}
@@ -0,0 +1,49 @@
+package lru
+
+import (
+	"strconv"
+	"testing"
+)
+
+// BenchmarkCacheSequential measures the unlocked Cache baseline. Cache is
+// explicitly not safe for concurrent use, so this is single-goroutine
+// only; it's the baseline BenchmarkSyncCacheSequential is compared
+// against to see the cost of the RWMutex.
+func BenchmarkCacheSequential(b *testing.B) {
+	c := New(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := strconv.Itoa(i % 2048)
+		c.Add(key, i)
+		c.Get(key)
+	}
+}
+
+// BenchmarkSyncCacheSequential measures SyncCache from a single
+// goroutine, isolating the RWMutex overhead from any contention.
+func BenchmarkSyncCacheSequential(b *testing.B) {
+	c := NewSync(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := strconv.Itoa(i % 2048)
+		c.Add(key, i)
+		c.Get(key)
+	}
+}
+
+// BenchmarkSyncCacheParallel measures SyncCache under concurrent load
+// from multiple goroutines, showing the contention behavior that made
+// SyncCache necessary in the first place.
+func BenchmarkSyncCacheParallel(b *testing.B) {
+	c := NewSync(1024)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := strconv.Itoa(i % 2048)
+			c.Add(key, i)
+			c.Get(key)
+			i++
+		}
+	})
+}
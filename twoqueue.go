@@ -0,0 +1,181 @@
+package lru
+
+import "time"
+
+const (
+	// Default2QRecentRatio is the default ratio of the cache size used
+	// for the "recent" list, which holds entries seen only once.
+	Default2QRecentRatio = 0.25
+	// Default2QGhostRatio is the default ratio of the cache size used
+	// for the "recent evicted" ghost list, which tracks keys evicted
+	// from the recent list so they can be promoted straight into the
+	// frequent list if they are re-added.
+	Default2QGhostRatio = 0.5
+)
+
+// TwoQueueCache implements the 2Q scan-resistant cache replacement
+// policy. It keeps three internal LRUs: a "recent" list for entries
+// seen once, a "frequent" list for entries promoted on a second hit,
+// and a "recent evicted" ghost list of keys only, used to detect that
+// an entry that was just evicted is being re-added so it can be
+// promoted directly into frequent.
+type TwoQueueCache struct {
+	Expiry time.Duration
+
+	// OnEvicted optionally specifies a callback function to be
+	// executed when an entry is purged from the cache.
+	OnEvicted func(key string, value interface{})
+
+	size        int
+	recentSize  int
+	recent      *Cache
+	frequent    *Cache
+	recentEvict *Cache
+
+	// suppress is set around the Remove half of a recent->frequent
+	// promotion, so the promotion's internal removal does not get
+	// reported to OnEvicted: the entry isn't leaving the cache, just
+	// moving queues.
+	suppress bool
+}
+
+func NewTwoQueueCache(size int, recentRatio, ghostRatio float64, options ...func(*TwoQueueCache)) *TwoQueueCache {
+	if recentRatio <= 0 {
+		recentRatio = Default2QRecentRatio
+	}
+	if ghostRatio <= 0 {
+		ghostRatio = Default2QGhostRatio
+	}
+	c := &TwoQueueCache{
+		size:       size,
+		recentSize: int(float64(size) * recentRatio),
+		recent:     New(0),
+		frequent:   New(0),
+	}
+	c.recentEvict = New(int(float64(size) * ghostRatio))
+	forward := func(key string, value interface{}) {
+		if !c.suppress && c.OnEvicted != nil {
+			c.OnEvicted(key, value)
+		}
+	}
+	c.recent.OnEvicted = forward
+	c.frequent.OnEvicted = forward
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func WithExpiry2Q(expiry time.Duration) func(c *TwoQueueCache) {
+	return func(c *TwoQueueCache) {
+		c.Expiry = expiry
+		c.recent.Expiry = expiry
+		c.frequent.Expiry = expiry
+	}
+}
+
+func WithEvictionCallback2Q(onEvicted func(key string, value interface{})) func(c *TwoQueueCache) {
+	return func(c *TwoQueueCache) {
+		c.OnEvicted = onEvicted
+	}
+}
+
+func (c *TwoQueueCache) Add(key string, value interface{}) {
+	if _, ok := c.frequent.Get(key); ok {
+		c.frequent.UpdateElement(key, value)
+		return
+	}
+	if _, ok := c.recent.Get(key); ok {
+		c.promote(key, value)
+		return
+	}
+	if _, ok := c.recentEvict.Get(key); ok {
+		c.recentEvict.Remove(key)
+		c.ensureSpace()
+		c.frequent.Add(key, value)
+		return
+	}
+	c.ensureSpace()
+	c.recent.Add(key, value)
+}
+
+func (c *TwoQueueCache) Get(key string) (value interface{}, ok bool) {
+	if value, ok = c.frequent.Get(key); ok {
+		return value, true
+	}
+	if value, ok = c.recent.Get(key); ok {
+		c.promote(key, value)
+		return value, true
+	}
+	return nil, false
+}
+
+// promote moves key/value from recent to frequent without reporting the
+// move to OnEvicted: the entry is still live in the cache, just
+// recorded as having been referenced twice.
+func (c *TwoQueueCache) promote(key string, value interface{}) {
+	c.suppress = true
+	c.recent.Remove(key)
+	c.suppress = false
+	c.frequent.Add(key, value)
+}
+
+func (c *TwoQueueCache) Remove(key string) {
+	if _, ok := c.frequent.Get(key); ok {
+		c.frequent.Remove(key)
+		return
+	}
+	if _, ok := c.recent.Get(key); ok {
+		c.recent.Remove(key)
+		return
+	}
+	c.recentEvict.Remove(key)
+}
+
+func (c *TwoQueueCache) ensureSpace() {
+	if c.recent.Len()+c.frequent.Len() < c.size {
+		return
+	}
+	if c.recent.Len() > c.recentSize || (c.recent.Len() > 0 && c.frequent.Len() == 0) {
+		c.evictFromRecent()
+		return
+	}
+	c.evictFrom(c.frequent)
+}
+
+// evictFromRecent evicts the oldest entry in recent into the recentEvict
+// ghost list. recent.OnEvicted is wired to the outer OnEvicted, so the
+// eviction is reported there.
+func (c *TwoQueueCache) evictFromRecent() {
+	ele := c.recent.ll.Back()
+	if ele == nil {
+		c.evictFrom(c.frequent)
+		return
+	}
+	kv := ele.Value.(*entry)
+	c.recent.Remove(kv.key)
+	c.recentEvict.Add(kv.key, nil)
+}
+
+// evictFrom evicts the oldest entry in q. q.OnEvicted is wired to the
+// outer OnEvicted, so the eviction is reported there.
+func (c *TwoQueueCache) evictFrom(q *Cache) {
+	ele := q.ll.Back()
+	if ele == nil {
+		return
+	}
+	kv := ele.Value.(*entry)
+	q.Remove(kv.key)
+}
+
+// Len returns the number of items in the cache.
+func (c *TwoQueueCache) Len() int {
+	return c.recent.Len() + c.frequent.Len()
+}
+
+// Clear purges all stored items from the cache.
+func (c *TwoQueueCache) Clear() {
+	c.recent.Clear()
+	c.frequent.Clear()
+	c.recentEvict.Clear()
+}
@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTwoQueueCacheAddGetRemove(t *testing.T) {
+	c := NewTwoQueueCache(4, 0, 0)
+	c.Add("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be removed")
+	}
+}
+
+func TestTwoQueueCacheStaysWithinSize(t *testing.T) {
+	c := NewTwoQueueCache(4, 0, 0)
+	for i := 0; i < 100; i++ {
+		c.Add(string(rune('a'+i%26)), i)
+		if c.Len() > 4 {
+			t.Fatalf("Len() = %d, want <= 4 after inserting %d entries", c.Len(), i+1)
+		}
+	}
+}
+
+func TestTwoQueueCachePromotesOnSecondHit(t *testing.T) {
+	c := NewTwoQueueCache(4, 0, 0)
+	c.Add("a", 1)
+	if c.recent.Len() != 1 || c.frequent.Len() != 0 {
+		t.Fatalf("after one Add, recent=%d frequent=%d; want recent=1 frequent=0", c.recent.Len(), c.frequent.Len())
+	}
+	c.Get("a")
+	if c.recent.Len() != 0 || c.frequent.Len() != 1 {
+		t.Fatalf("after a second reference, recent=%d frequent=%d; want recent=0 frequent=1", c.recent.Len(), c.frequent.Len())
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1 (promotion must not duplicate the entry)", c.Len())
+	}
+}
+
+func TestTwoQueueCacheGhostPromotesDirectlyToFrequent(t *testing.T) {
+	c := NewTwoQueueCache(2, 0.5, 1)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3) // evicts a from recent into the recentEvict ghost list
+	c.Add("a", 4) // re-adding a ghost hit should promote straight into frequent
+	if c.frequent.Len() != 1 {
+		t.Fatalf("frequent.Len() = %d, want 1 after a ghost hit", c.frequent.Len())
+	}
+	if v, ok := c.Get("a"); !ok || v != 4 {
+		t.Fatalf("Get(a) = %v, %v; want 4, true", v, ok)
+	}
+}
+
+func TestTwoQueueCacheOnEvictedFiresOnSizeEviction(t *testing.T) {
+	var evicted []string
+	c := NewTwoQueueCache(2, 0, 0, WithEvictionCallback2Q(func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	}))
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+	if len(evicted) == 0 {
+		t.Fatalf("expected OnEvicted to fire on size-based eviction")
+	}
+}
+
+func TestTwoQueueCacheOnEvictedFiresOnExpiry(t *testing.T) {
+	var evicted []string
+	c := NewTwoQueueCache(4, 0, 0,
+		WithExpiry2Q(10*time.Millisecond),
+		WithEvictionCallback2Q(func(key string, value interface{}) {
+			evicted = append(evicted, key)
+		}),
+	)
+	c.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to have expired")
+	}
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("evicted = %v, want [a] (expiry must be reported to OnEvicted)", evicted)
+	}
+}
+
+func TestTwoQueueCachePromotionDoesNotFireOnEvicted(t *testing.T) {
+	var evicted []string
+	c := NewTwoQueueCache(4, 0, 0, WithEvictionCallback2Q(func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	}))
+	c.Add("a", 1)
+	c.Get("a")
+	if len(evicted) != 0 {
+		t.Fatalf("evicted = %v, want none; promoting a from recent to frequent must not evict it", evicted)
+	}
+}
+
+func TestTwoQueueCacheClear(t *testing.T) {
+	c := NewTwoQueueCache(4, 0, 0)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Clear()
+	if c.Len() != 0 {
+		t.Fatalf("Len() after Clear() = %d, want 0", c.Len())
+	}
+}